@@ -0,0 +1,76 @@
+package flowcontrol
+
+import (
+	"time"
+
+	"github.com/lucas-clemente/quic-go/protocol"
+)
+
+// bandwidthSampleCount is the number of (time, bytesRead) samples kept by the
+// bandwidthEstimator. This mirrors the small windows used by TCP/QUIC
+// congestion controllers for smoothing delivery rate estimates.
+const bandwidthSampleCount = 5
+
+type bandwidthSample struct {
+	time      time.Time
+	bytesRead protocol.ByteCount
+}
+
+// bandwidthEstimator keeps a small ring of receive throughput samples and
+// derives a smoothed delivery rate from them. It is used to compute the
+// bandwidth-delay product for auto-tuning the flow control receive window.
+type bandwidthEstimator struct {
+	samples [bandwidthSampleCount]bandwidthSample
+	// number of samples currently stored, 0..bandwidthSampleCount
+	numSamples int
+	// index the next sample will be written to
+	next int
+}
+
+func newBandwidthEstimator() *bandwidthEstimator {
+	return &bandwidthEstimator{}
+}
+
+// addSample records a new (time, bytesRead) data point. Samples with a
+// timestamp not strictly greater than the most recently recorded one are
+// ignored, since the estimator requires monotonically increasing timestamps.
+func (e *bandwidthEstimator) addSample(t time.Time, bytesRead protocol.ByteCount) {
+	if e.numSamples > 0 {
+		last := e.samples[(e.next-1+bandwidthSampleCount)%bandwidthSampleCount]
+		if !t.After(last.time) {
+			return
+		}
+	}
+
+	e.samples[e.next] = bandwidthSample{time: t, bytesRead: bytesRead}
+	e.next = (e.next + 1) % bandwidthSampleCount
+	if e.numSamples < bandwidthSampleCount {
+		e.numSamples++
+	}
+}
+
+// bandwidth returns the smoothed delivery rate in bytes per second, computed
+// from the oldest and newest samples currently stored. The second return
+// value is false if there aren't enough samples yet to produce an estimate.
+func (e *bandwidthEstimator) bandwidth() (protocol.ByteCount, bool) {
+	if e.numSamples < 2 {
+		return 0, false
+	}
+
+	oldestIndex := e.next % bandwidthSampleCount
+	if e.numSamples < bandwidthSampleCount {
+		oldestIndex = 0
+	}
+	newestIndex := (e.next - 1 + bandwidthSampleCount) % bandwidthSampleCount
+
+	oldest := e.samples[oldestIndex]
+	newest := e.samples[newestIndex]
+
+	elapsed := newest.time.Sub(oldest.time)
+	if elapsed <= 0 || newest.bytesRead <= oldest.bytesRead {
+		return 0, false
+	}
+
+	bytes := newest.bytesRead - oldest.bytesRead
+	return protocol.ByteCount(float64(bytes) / elapsed.Seconds()), true
+}
@@ -0,0 +1,65 @@
+package flowcontrol
+
+import (
+	"time"
+
+	"github.com/lucas-clemente/quic-go/protocol"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bandwidth Estimator", func() {
+	var estimator *bandwidthEstimator
+	var baseTime time.Time
+
+	BeforeEach(func() {
+		estimator = newBandwidthEstimator()
+		baseTime = time.Now()
+	})
+
+	It("returns no estimate before any samples are added", func() {
+		_, ok := estimator.bandwidth()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("returns no estimate after a single sample", func() {
+		estimator.addSample(baseTime, 1000)
+		_, ok := estimator.bandwidth()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("computes the delivery rate from two samples", func() {
+		estimator.addSample(baseTime, 0)
+		estimator.addSample(baseTime.Add(time.Second), 1000)
+		bandwidth, ok := estimator.bandwidth()
+		Expect(ok).To(BeTrue())
+		Expect(bandwidth).To(Equal(protocol.ByteCount(1000)))
+	})
+
+	It("only keeps the last N samples", func() {
+		for i := 0; i < bandwidthSampleCount+2; i++ {
+			estimator.addSample(baseTime.Add(time.Duration(i)*time.Second), protocol.ByteCount(i*1000))
+		}
+		// the oldest two samples should have been evicted, leaving a window
+		// spanning bandwidthSampleCount-1 seconds and bandwidthSampleCount-1 kB
+		bandwidth, ok := estimator.bandwidth()
+		Expect(ok).To(BeTrue())
+		Expect(bandwidth).To(Equal(protocol.ByteCount(1000)))
+	})
+
+	It("ignores samples with a non-increasing timestamp", func() {
+		estimator.addSample(baseTime, 0)
+		estimator.addSample(baseTime, 500)
+		estimator.addSample(baseTime.Add(-time.Second), 1000)
+		_, ok := estimator.bandwidth()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("returns no estimate if no bytes were read between samples", func() {
+		estimator.addSample(baseTime, 1000)
+		estimator.addSample(baseTime.Add(time.Second), 1000)
+		_, ok := estimator.bandwidth()
+		Expect(ok).To(BeFalse())
+	})
+})
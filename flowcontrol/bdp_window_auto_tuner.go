@@ -0,0 +1,51 @@
+package flowcontrol
+
+import (
+	"time"
+
+	"github.com/lucas-clemente/quic-go/protocol"
+	"github.com/lucas-clemente/quic-go/utils"
+)
+
+// bdpWindowHeadroom is the multiplier applied to the measured bandwidth-delay
+// product when deriving a target receive window increment from it. Sizing
+// the window at a multiple of the BDP, rather than exactly at it, leaves
+// enough slack for the peer to keep sending while the WINDOW_UPDATE is in
+// flight.
+const bdpWindowHeadroom = 2
+
+// bdpWindowAutoTuner sizes the receive window increment from the measured
+// bandwidth-delay product of the connection. It falls back to the classic
+// doubling behavior as long as no bandwidth estimate is available yet, e.g.
+// early in the connection.
+type bdpWindowAutoTuner struct {
+	estimator *bandwidthEstimator
+	fallback  WindowAutoTuner
+}
+
+// newBDPWindowAutoTuner creates a WindowAutoTuner that derives the window
+// increment from a bandwidth-delay product estimate.
+func newBDPWindowAutoTuner() *bdpWindowAutoTuner {
+	return &bdpWindowAutoTuner{
+		estimator: newBandwidthEstimator(),
+		fallback:  newWindowDoublingAutoTuner(),
+	}
+}
+
+func (t *bdpWindowAutoTuner) AddBytesRead(time time.Time, bytesRead protocol.ByteCount) {
+	t.estimator.addSample(time, bytesRead)
+}
+
+func (t *bdpWindowAutoTuner) NextIncrement(currentIncrement, maxIncrement protocol.ByteCount, rtt time.Duration) protocol.ByteCount {
+	bandwidth, ok := t.estimator.bandwidth()
+	if !ok {
+		return t.fallback.NextIncrement(currentIncrement, maxIncrement, rtt)
+	}
+
+	bdp := protocol.ByteCount(float64(bandwidth) * rtt.Seconds())
+	targetIncrement := bdpWindowHeadroom * bdp
+	if targetIncrement < currentIncrement {
+		targetIncrement = currentIncrement
+	}
+	return utils.MinByteCount(targetIncrement, maxIncrement)
+}
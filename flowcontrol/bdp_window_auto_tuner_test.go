@@ -0,0 +1,51 @@
+package flowcontrol
+
+import (
+	"time"
+
+	"github.com/lucas-clemente/quic-go/protocol"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BDP Window Auto Tuner", func() {
+	var tuner *bdpWindowAutoTuner
+	var baseTime time.Time
+
+	BeforeEach(func() {
+		tuner = newBDPWindowAutoTuner()
+		baseTime = time.Now()
+	})
+
+	It("falls back to doubling when no bandwidth estimate is available", func() {
+		next := tuner.NextIncrement(100, 10000, 50*time.Millisecond)
+		Expect(next).To(Equal(protocol.ByteCount(200)))
+	})
+
+	It("derives the increment from the measured bandwidth-delay product", func() {
+		tuner.AddBytesRead(baseTime, 0)
+		tuner.AddBytesRead(baseTime.Add(time.Second), 100000) // 100 kB/s
+
+		rtt := 100 * time.Millisecond
+		next := tuner.NextIncrement(100, 1000000, rtt)
+		// BDP = 100 kB/s * 100ms = 10 kB, with 2x headroom -> 20 kB
+		Expect(next).To(Equal(protocol.ByteCount(20000)))
+	})
+
+	It("never returns less than the current increment", func() {
+		tuner.AddBytesRead(baseTime, 0)
+		tuner.AddBytesRead(baseTime.Add(time.Second), 100) // tiny bandwidth
+
+		next := tuner.NextIncrement(50000, 1000000, 100*time.Millisecond)
+		Expect(next).To(Equal(protocol.ByteCount(50000)))
+	})
+
+	It("clamps to the maximum increment", func() {
+		tuner.AddBytesRead(baseTime, 0)
+		tuner.AddBytesRead(baseTime.Add(time.Second), 100000000) // 100 MB/s
+
+		next := tuner.NextIncrement(100, 1000000, 100*time.Millisecond)
+		Expect(next).To(Equal(protocol.ByteCount(1000000)))
+	})
+})
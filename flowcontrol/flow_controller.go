@@ -26,13 +26,24 @@ type flowController struct {
 	receiveWindow             protocol.ByteCount
 	receiveWindowIncrement    protocol.ByteCount
 	maxReceiveWindowIncrement protocol.ByteCount
+
+	autoTuner WindowAutoTuner
+
+	// currentTime is used to obtain the current time. It is a variable so that
+	// tests can inject a fake clock.
+	currentTime func() time.Time
+
+	blockedFrameSent    bool
+	lastBlockedAtOffset protocol.ByteCount
 }
 
 // ErrReceivedSmallerByteOffset occurs if the ByteOffset received is smaller than a ByteOffset that was set previously
 var ErrReceivedSmallerByteOffset = errors.New("Received a smaller byte offset")
 
-// newFlowController gets a new flow controller
-func newFlowController(streamID protocol.StreamID, connectionParameters handshake.ConnectionParametersManager, rttStats *congestion.RTTStats) *flowController {
+// NewFlowController creates a new flow controller. The autoTuner determines
+// how the receive window increment is grown when WINDOW_UPDATEs are sent too
+// often; passing nil selects the bandwidth-delay-product based default.
+func NewFlowController(streamID protocol.StreamID, connectionParameters handshake.ConnectionParametersManager, rttStats *congestion.RTTStats, autoTuner WindowAutoTuner) FlowController {
 	fc := flowController{
 		streamID:             streamID,
 		connectionParameters: connectionParameters,
@@ -49,9 +60,20 @@ func newFlowController(streamID protocol.StreamID, connectionParameters handshak
 		fc.maxReceiveWindowIncrement = connectionParameters.GetMaxReceiveStreamFlowControlWindow()
 	}
 
+	if autoTuner == nil {
+		autoTuner = newBDPWindowAutoTuner()
+	}
+	fc.autoTuner = autoTuner
+	fc.currentTime = time.Now
+
 	return &fc
 }
 
+// newFlowController gets a new flow controller using the default (BDP-based) auto-tuning strategy
+func newFlowController(streamID protocol.StreamID, connectionParameters handshake.ConnectionParametersManager, rttStats *congestion.RTTStats) *flowController {
+	return NewFlowController(streamID, connectionParameters, rttStats, nil).(*flowController)
+}
+
 func (c *flowController) getSendWindow() protocol.ByteCount {
 	if c.sendWindow == 0 {
 		if c.streamID == 0 {
@@ -114,6 +136,7 @@ func (c *flowController) IncrementHighestReceived(increment protocol.ByteCount)
 
 func (c *flowController) AddBytesRead(n protocol.ByteCount) {
 	c.bytesRead += n
+	c.autoTuner.AddBytesRead(c.currentTime(), c.bytesRead)
 }
 
 // MaybeUpdateWindow determines if it is necessary to send a WindowUpdate
@@ -124,7 +147,7 @@ func (c *flowController) MaybeUpdateWindow() (bool, protocol.ByteCount) {
 	// Chromium implements the same threshold
 	if diff < (c.receiveWindowIncrement / 2) {
 		c.maybeAdjustWindowIncrement()
-		c.lastWindowUpdateTime = time.Now()
+		c.lastWindowUpdateTime = c.currentTime()
 		c.receiveWindow = c.bytesRead + c.receiveWindowIncrement
 		return true, c.receiveWindow
 	}
@@ -132,7 +155,8 @@ func (c *flowController) MaybeUpdateWindow() (bool, protocol.ByteCount) {
 	return false, 0
 }
 
-// maybeAdjustWindowIncrement increases the receiveWindowIncrement if we're sending WindowUpdates too often
+// maybeAdjustWindowIncrement increases the receiveWindowIncrement if we're sending WindowUpdates too often.
+// The actual growth policy is delegated to the configured WindowAutoTuner.
 func (c *flowController) maybeAdjustWindowIncrement() {
 	if c.lastWindowUpdateTime.IsZero() {
 		return
@@ -143,7 +167,7 @@ func (c *flowController) maybeAdjustWindowIncrement() {
 		return
 	}
 
-	timeSinceLastWindowUpdate := time.Now().Sub(c.lastWindowUpdateTime)
+	timeSinceLastWindowUpdate := c.currentTime().Sub(c.lastWindowUpdateTime)
 
 	// interval between the window updates is sufficiently large, no need to increase the increment
 	if timeSinceLastWindowUpdate >= 2*rtt {
@@ -151,7 +175,7 @@ func (c *flowController) maybeAdjustWindowIncrement() {
 	}
 
 	oldWindowSize := c.receiveWindowIncrement
-	c.receiveWindowIncrement = utils.MinByteCount(2*c.receiveWindowIncrement, c.maxReceiveWindowIncrement)
+	c.receiveWindowIncrement = c.autoTuner.NextIncrement(c.receiveWindowIncrement, c.maxReceiveWindowIncrement, rtt)
 
 	// debug log, if the window size was actually increased
 	if oldWindowSize < c.receiveWindowIncrement {
@@ -170,3 +194,29 @@ func (c *flowController) CheckFlowControlViolation() bool {
 	}
 	return false
 }
+
+// IsBlocked says if the flow controller is blocked, i.e. the send window is completely used up.
+// If it is blocked, it also returns the offset of the send window.
+func (c *flowController) IsBlocked() (bool, protocol.ByteCount) {
+	if c.SendWindowSize() != 0 {
+		return false, 0
+	}
+	return true, c.getSendWindow()
+}
+
+// ShouldSendBlockedFrame says if a BLOCKED frame should be sent. It debounces sending BLOCKED
+// frames, since a peer that doesn't update its window won't become unblocked just because we
+// tell it more than once. It returns true at most once per send window offset.
+func (c *flowController) ShouldSendBlockedFrame() bool {
+	blocked, offset := c.IsBlocked()
+	if !blocked {
+		return false
+	}
+	if c.blockedFrameSent && c.lastBlockedAtOffset == offset {
+		return false
+	}
+
+	c.blockedFrameSent = true
+	c.lastBlockedAtOffset = offset
+	return true
+}
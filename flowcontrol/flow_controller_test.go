@@ -0,0 +1,62 @@
+package flowcontrol
+
+import (
+	"github.com/lucas-clemente/quic-go/protocol"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Blocked signaling", func() {
+	var controller *flowController
+
+	BeforeEach(func() {
+		controller = &flowController{
+			streamID:   5,
+			sendWindow: 100,
+			autoTuner:  newWindowDoublingAutoTuner(),
+		}
+	})
+
+	It("is not blocked when the send window isn't used up", func() {
+		controller.AddBytesSent(50)
+		blocked, _ := controller.IsBlocked()
+		Expect(blocked).To(BeFalse())
+	})
+
+	It("is blocked when the send window is completely used up", func() {
+		controller.AddBytesSent(100)
+		blocked, offset := controller.IsBlocked()
+		Expect(blocked).To(BeTrue())
+		Expect(offset).To(Equal(protocol.ByteCount(100)))
+	})
+
+	It("says a BLOCKED frame should be sent when newly blocked", func() {
+		controller.AddBytesSent(100)
+		Expect(controller.ShouldSendBlockedFrame()).To(BeTrue())
+	})
+
+	It("doesn't say a BLOCKED frame should be sent when not blocked", func() {
+		controller.AddBytesSent(50)
+		Expect(controller.ShouldSendBlockedFrame()).To(BeFalse())
+	})
+
+	It("only sends one BLOCKED frame per send window offset", func() {
+		controller.AddBytesSent(100)
+		Expect(controller.ShouldSendBlockedFrame()).To(BeTrue())
+		Expect(controller.ShouldSendBlockedFrame()).To(BeFalse())
+		Expect(controller.ShouldSendBlockedFrame()).To(BeFalse())
+	})
+
+	It("sends another BLOCKED frame once the window is updated and used up again", func() {
+		controller.AddBytesSent(100)
+		Expect(controller.ShouldSendBlockedFrame()).To(BeTrue())
+		Expect(controller.ShouldSendBlockedFrame()).To(BeFalse())
+
+		controller.UpdateSendWindow(200)
+		Expect(controller.ShouldSendBlockedFrame()).To(BeFalse())
+
+		controller.AddBytesSent(100)
+		Expect(controller.ShouldSendBlockedFrame()).To(BeTrue())
+	})
+})
@@ -0,0 +1,13 @@
+package flowcontrol
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestFlowcontrol(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Flow Control Suite")
+}
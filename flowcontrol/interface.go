@@ -0,0 +1,44 @@
+package flowcontrol
+
+import (
+	"time"
+
+	"github.com/lucas-clemente/quic-go/protocol"
+)
+
+// FlowController is the interface implemented by both stream-level and
+// connection-level flow controllers.
+type FlowController interface {
+	// for sending
+	AddBytesSent(protocol.ByteCount)
+	UpdateSendWindow(protocol.ByteCount) bool
+	SendWindowSize() protocol.ByteCount
+	SendWindowOffset() protocol.ByteCount
+
+	// for receiving
+	AddBytesRead(protocol.ByteCount)
+	UpdateHighestReceived(protocol.ByteCount) (protocol.ByteCount, error)
+	IncrementHighestReceived(protocol.ByteCount)
+	MaybeUpdateWindow() (bool, protocol.ByteCount)
+	CheckFlowControlViolation() bool
+
+	// for BLOCKED signaling
+	IsBlocked() (bool, protocol.ByteCount)
+	ShouldSendBlockedFrame() bool
+}
+
+// WindowAutoTuner decides how much the receive window increment should grow
+// by whenever the flow controller determines that WINDOW_UPDATEs are being
+// sent too often. Implementations may keep their own state (e.g. a receive
+// throughput history) across calls.
+type WindowAutoTuner interface {
+	// AddBytesRead is called every time the flow controller learns about
+	// newly read bytes, so the tuner can maintain a receive throughput
+	// history if it needs one.
+	AddBytesRead(t time.Time, bytesRead protocol.ByteCount)
+	// NextIncrement returns the receiveWindowIncrement to use next, given the
+	// current increment, the maximum allowed increment and the current
+	// smoothed RTT. It must never return a value smaller than
+	// currentIncrement or larger than maxIncrement.
+	NextIncrement(currentIncrement, maxIncrement protocol.ByteCount, rtt time.Duration) protocol.ByteCount
+}
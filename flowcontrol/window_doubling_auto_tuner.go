@@ -0,0 +1,25 @@
+package flowcontrol
+
+import (
+	"time"
+
+	"github.com/lucas-clemente/quic-go/protocol"
+	"github.com/lucas-clemente/quic-go/utils"
+)
+
+// windowDoublingAutoTuner is the original auto-tuning strategy: whenever the
+// receive window increment turns out to be too small, it is simply doubled,
+// up to maxIncrement.
+type windowDoublingAutoTuner struct{}
+
+// newWindowDoublingAutoTuner creates a WindowAutoTuner that doubles the
+// window increment on every adjustment.
+func newWindowDoublingAutoTuner() *windowDoublingAutoTuner {
+	return &windowDoublingAutoTuner{}
+}
+
+func (t *windowDoublingAutoTuner) AddBytesRead(time.Time, protocol.ByteCount) {}
+
+func (t *windowDoublingAutoTuner) NextIncrement(currentIncrement, maxIncrement protocol.ByteCount, rtt time.Duration) protocol.ByteCount {
+	return utils.MinByteCount(2*currentIncrement, maxIncrement)
+}
@@ -0,0 +1,24 @@
+package flowcontrol
+
+import (
+	"time"
+
+	"github.com/lucas-clemente/quic-go/protocol"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Window Doubling Auto Tuner", func() {
+	It("doubles the current increment", func() {
+		tuner := newWindowDoublingAutoTuner()
+		next := tuner.NextIncrement(100, 1000, 50*time.Millisecond)
+		Expect(next).To(Equal(protocol.ByteCount(200)))
+	})
+
+	It("clamps to the maximum increment", func() {
+		tuner := newWindowDoublingAutoTuner()
+		next := tuner.NextIncrement(700, 1000, 50*time.Millisecond)
+		Expect(next).To(Equal(protocol.ByteCount(1000)))
+	})
+})